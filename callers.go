@@ -24,7 +24,6 @@
 package callers
 
 import (
-	"bytes"
 	"fmt"
 	"path/filepath"
 	"runtime"
@@ -33,12 +32,20 @@ import (
 
 // Frame contains file/function/line information for a stack frame
 type Frame struct {
+	// the program counter for this frame, as returned by runtime.Callers
+	PC uintptr
 	// the source file where the call was made
 	File string
 	// the line in the source file where the call was made
 	Line int
 	// the function where the call was made
 	Function string
+	// the lines of source surrounding the call site, populated by Source
+	// or CallersWithSource; nil until then
+	SourceLines []string
+	// the absolute path to File, kept for Source's benefit since File is
+	// trimmed down to its import path or base name for display
+	absFile string
 }
 
 // String returns a simple string representation of the Frame
@@ -46,22 +53,24 @@ func (f *Frame) String() string {
 	return fmt.Sprintf("File: %s Line: %d Function: %s", f.File, f.Line, f.Function)
 }
 
-// String prints a slice of *Frames to a string one per line.
-// "indent" is printed at the beginning of each line
-func String(trace []*Frame, indent string) string {
-	var buf = &bytes.Buffer{}
-	for _, frame := range trace {
-		fmt.Fprint(buf, indent)
-		fmt.Fprintln(buf, frame)
-	}
-	return buf.String()
-}
+// Trace is a sequence of Frames, typically as returned by Callers, in
+// order from innermost to outermost.
+type Trace []*Frame
+
+// inlinePadding is the number of extra pc slots requested from
+// runtime.Callers beyond "depth". Since Go 1.9, the compiler can inline
+// functions, which means a single physical pc can expand into several
+// logical frames once handed to runtime.CallersFrames. Without the padding,
+// sizing the pc buffer exactly to "depth" can cause inlined callers near
+// the end of the requested range to be silently dropped. go-stack uses the
+// same trick to keep inlined frames intact.
+const inlinePadding = 10
 
 // Callers is a handy wrapper around runtime.Callers and
 // runtime.CallersFrames. The argument "skip" is the number
 // of stack frames to skip before collecting frames. The
 // "depth" argument is the number of stack frames to collect.
-func Callers(skip, depth int) (trace []*Frame) {
+func Callers(skip, depth int) (trace Trace) {
 	if skip < 0 {
 		skip = 0
 	}
@@ -69,26 +78,38 @@ func Callers(skip, depth int) (trace []*Frame) {
 		depth = 10
 	}
 
-	trace = make([]*Frame, 0)
-	var pc = make([]uintptr, depth)
+	trace = make(Trace, 0, depth)
+	var pc = make([]uintptr, depth+inlinePadding)
 	var n = runtime.Callers(skip, pc)
+	if n == 0 {
+		return trace
+	}
 	var fs = runtime.CallersFrames(pc[:n])
-	var f, ok = fs.Next()
-	for ok {
+	for {
+		var f, more = fs.Next()
 		var frame = &Frame{
+			PC:       f.PC,
 			Line:     f.Line,
 			Function: f.Function,
 		}
-		var file = filepath.ToSlash(f.File)
-		if n := strings.LastIndex(file, "/src/"); n > 0 {
-			file = file[n+5:]
-		} else {
-			file = filepath.Base(file)
-		}
-		frame.File = file
+		frame.absFile = f.File
+		frame.File = trimFile(f.File)
 
 		trace = append(trace, frame)
-		f, ok = fs.Next()
+		if len(trace) >= depth || !more {
+			break
+		}
 	}
 	return
 }
+
+// trimFile normalizes an absolute source path down to the part that's
+// actually useful: everything after "/src/" if present (e.g.
+// "import/path/file.go"), or just the base filename otherwise.
+func trimFile(file string) string {
+	file = filepath.ToSlash(file)
+	if n := strings.LastIndex(file, "/src/"); n > 0 {
+		return file[n+5:]
+	}
+	return filepath.Base(file)
+}
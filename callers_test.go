@@ -0,0 +1,49 @@
+package callers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCallersPopulatesFields(t *testing.T) {
+	var trace = Callers(0, 5)
+	if len(trace) == 0 {
+		t.Fatal("Callers returned no frames")
+	}
+	var f = trace[0]
+	if f.PC == 0 {
+		t.Error("PC is zero")
+	}
+	if f.File == "" {
+		t.Error("File is empty")
+	}
+	if !strings.Contains(f.Function, "Callers") {
+		t.Errorf("Function = %q, want it to mention Callers", f.Function)
+	}
+}
+
+func TestCallersDepthLimiting(t *testing.T) {
+	var trace Trace
+	var depth3 = func() { trace = Callers(0, 2) }
+	var depth2 = func() { depth3() }
+	var depth1 = func() { depth2() }
+	depth1()
+
+	if len(trace) != 2 {
+		t.Fatalf("len(trace) = %d, want 2", len(trace))
+	}
+}
+
+func TestCallersSkipExceedsStackDepth(t *testing.T) {
+	var trace = Callers(1000, 10)
+	if len(trace) != 0 {
+		t.Fatalf("Callers(1000, 10) = %v, want an empty trace", trace)
+	}
+}
+
+func TestCallersDefaultsDepth(t *testing.T) {
+	var trace = Callers(0, 0)
+	if len(trace) == 0 {
+		t.Fatal("Callers with depth <= 0 should fall back to a default depth, not return nothing")
+	}
+}
@@ -0,0 +1,107 @@
+package callers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// tracedError is an error that carries the stack trace captured at the
+// point it was created, in the style of pkg/errors and go-faster/errors.
+type tracedError struct {
+	msg   string
+	err   error
+	trace Trace
+}
+
+// New returns an error with the supplied message, along with the stack
+// trace captured at the point New was called.
+func New(msg string) error {
+	return &tracedError{msg: msg, trace: Callers(2, 32)}
+}
+
+// Errorf formats according to the given format specifier and returns an
+// error with the resulting message, along with the stack trace captured
+// at the point Errorf was called.
+func Errorf(format string, args ...any) error {
+	return &tracedError{msg: fmt.Sprintf(format, args...), trace: Callers(2, 32)}
+}
+
+// Wrap annotates err with msg and with the stack trace captured at the
+// point Wrap was called. If err is nil, Wrap returns nil.
+func Wrap(err error, msg string) error {
+	if err == nil {
+		return nil
+	}
+	return &tracedError{msg: msg, err: err, trace: Callers(2, 32)}
+}
+
+// Error implements the error interface.
+func (e *tracedError) Error() string {
+	if e.err != nil {
+		return e.msg + ": " + e.err.Error()
+	}
+	return e.msg
+}
+
+// Unwrap returns the wrapped error, if any, so that tracedError works
+// with errors.Is, errors.As and errors.Unwrap.
+func (e *tracedError) Unwrap() error {
+	return e.err
+}
+
+// StackTrace returns the Frames captured when e was created.
+func (e *tracedError) StackTrace() []*Frame {
+	return e.trace
+}
+
+// Format implements fmt.Formatter. %s and %v print the same message as
+// Error. %+v walks the Unwrap chain, printing each layer's message
+// followed by its captured frames, indented one level per layer.
+func (e *tracedError) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			formatChain(s, e)
+			return
+		}
+		io.WriteString(s, e.Error())
+	case 's':
+		io.WriteString(s, e.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", e.Error())
+	}
+}
+
+// formatChain writes err's Unwrap chain to w, indenting each layer's
+// message and frames one level deeper than the layer that wraps it.
+func formatChain(w io.Writer, err error) {
+	for depth := 0; err != nil; depth++ {
+		var indent = strings.Repeat("\t", depth)
+		if te, ok := err.(*tracedError); ok {
+			fmt.Fprintf(w, "%s%s\n", indent, te.msg)
+			for _, f := range te.trace {
+				var frame = strings.ReplaceAll(fmt.Sprintf("%+v", f), "\n", "\n"+indent)
+				fmt.Fprintf(w, "%s\t%s\n", indent, frame)
+			}
+		} else {
+			fmt.Fprintf(w, "%s%s\n", indent, err.Error())
+		}
+		err = errors.Unwrap(err)
+	}
+}
+
+// StackTrace walks err's Unwrap chain and returns the deepest attached
+// stack trace, i.e. the one captured closest to the original cause. It
+// returns nil if no error in the chain carries a trace.
+func StackTrace(err error) []*Frame {
+	var trace []*Frame
+	for err != nil {
+		if st, ok := err.(interface{ StackTrace() []*Frame }); ok {
+			trace = st.StackTrace()
+		}
+		err = errors.Unwrap(err)
+	}
+	return trace
+}
@@ -0,0 +1,90 @@
+package callers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestNewCapturesTrace(t *testing.T) {
+	var err = New("boom")
+	if err.Error() != "boom" {
+		t.Fatalf("Error() = %q, want %q", err.Error(), "boom")
+	}
+	var trace = StackTrace(err)
+	if len(trace) == 0 {
+		t.Fatal("StackTrace() returned no frames")
+	}
+	if trace[0].Function == "" {
+		t.Error("innermost frame has no Function")
+	}
+}
+
+func TestErrorf(t *testing.T) {
+	var err = Errorf("value was %d", 42)
+	if err.Error() != "value was 42" {
+		t.Errorf("Error() = %q, want %q", err.Error(), "value was 42")
+	}
+}
+
+func TestWrapNil(t *testing.T) {
+	if Wrap(nil, "msg") != nil {
+		t.Error("Wrap(nil, ...) should return nil")
+	}
+}
+
+func TestWrapChain(t *testing.T) {
+	var root = New("root cause")
+	var wrapped = Wrap(root, "while doing work")
+
+	if wrapped.Error() != "while doing work: root cause" {
+		t.Errorf("Error() = %q, want %q", wrapped.Error(), "while doing work: root cause")
+	}
+	if !errors.Is(wrapped, root) {
+		t.Error("errors.Is(wrapped, root) = false, want true")
+	}
+	if errors.Unwrap(wrapped) != root {
+		t.Error("errors.Unwrap(wrapped) != root")
+	}
+}
+
+func TestStackTraceReturnsDeepest(t *testing.T) {
+	var root = New("root cause")
+	var wrapped = Wrap(root, "while doing work")
+
+	var rootTrace = StackTrace(root)
+	var gotTrace = StackTrace(wrapped)
+	if len(gotTrace) != len(rootTrace) || (len(gotTrace) > 0 && gotTrace[0] != rootTrace[0]) {
+		t.Errorf("StackTrace(wrapped) did not return root's trace")
+	}
+}
+
+func TestStackTraceNoTrace(t *testing.T) {
+	if trace := StackTrace(errors.New("plain")); trace != nil {
+		t.Errorf("StackTrace(plain error) = %v, want nil", trace)
+	}
+}
+
+func TestTracedErrorFormatPlusV(t *testing.T) {
+	var root = New("root cause")
+	var wrapped = Wrap(root, "while doing work")
+
+	var out = fmt.Sprintf("%+v", wrapped)
+	if !strings.Contains(out, "while doing work") {
+		t.Error("Format(+v) output missing outer message")
+	}
+	if !strings.Contains(out, "root cause") {
+		t.Error("Format(+v) output missing inner message")
+	}
+	if !strings.Contains(out, "\t\t") {
+		t.Error("Format(+v) output does not indent the inner layer deeper than the outer")
+	}
+}
+
+func TestTracedErrorFormatV(t *testing.T) {
+	var err = New("boom")
+	if got := fmt.Sprintf("%v", err); got != "boom" {
+		t.Errorf("%%v = %q, want %q", got, "boom")
+	}
+}
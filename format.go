@@ -0,0 +1,102 @@
+package callers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Format implements fmt.Formatter, in the style of github.com/go-stack/stack.
+//
+//	%s    base filename, e.g. callers.go
+//	%d    line number
+//	%n    bare function name, e.g. Callers
+//	%k    package name, e.g. callers
+//	%+s   "import/path/file.go" instead of just the base filename
+//	%v    equivalent to Frame.String()
+//	%+v   "function\n\tfile:line", matching the Azure diag StackTrace layout
+func (f *Frame) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 's':
+		if s.Flag('+') {
+			io.WriteString(s, f.File)
+		} else {
+			io.WriteString(s, filepath.Base(f.File))
+		}
+	case 'd':
+		io.WriteString(s, strconv.Itoa(f.Line))
+	case 'n':
+		io.WriteString(s, funcname(f.Function))
+	case 'k':
+		io.WriteString(s, pkgname(f.Function))
+	case 'v':
+		if s.Flag('+') {
+			io.WriteString(s, f.Function)
+			io.WriteString(s, "\n\t")
+			io.WriteString(s, f.File)
+			io.WriteString(s, ":")
+			io.WriteString(s, strconv.Itoa(f.Line))
+		} else {
+			io.WriteString(s, f.String())
+		}
+	}
+}
+
+// funcname strips the package path from a fully-qualified function name,
+// e.g. "github.com/jgpruitt/callers.Callers" becomes "Callers".
+func funcname(function string) string {
+	if i := strings.LastIndex(function, "/"); i >= 0 {
+		function = function[i+1:]
+	}
+	if i := strings.Index(function, "."); i >= 0 {
+		function = function[i+1:]
+	}
+	return function
+}
+
+// pkgname returns the package name of a fully-qualified function name,
+// e.g. "github.com/jgpruitt/callers.Callers" becomes "callers".
+func pkgname(function string) string {
+	if i := strings.LastIndex(function, "/"); i >= 0 {
+		function = function[i+1:]
+	}
+	if i := strings.Index(function, "."); i >= 0 {
+		function = function[:i]
+	}
+	return function
+}
+
+// String prints the Trace one Frame per line.
+func (t Trace) String() string {
+	var buf bytes.Buffer
+	for _, f := range t {
+		fmt.Fprintln(&buf, f)
+	}
+	return buf.String()
+}
+
+// Format implements fmt.Formatter. The plain %v verb renders the same as
+// String. The %+v verb renders each Frame via Frame's %+v (two lines per
+// Frame), and honors a width flag as the number of spaces to indent every
+// line with, e.g. fmt.Sprintf("%+20v", trace).
+func (t Trace) Format(s fmt.State, verb rune) {
+	if verb != 'v' {
+		return
+	}
+	if !s.Flag('+') {
+		io.WriteString(s, t.String())
+		return
+	}
+	var indent string
+	if width, ok := s.Width(); ok {
+		indent = strings.Repeat(" ", width)
+	}
+	for _, f := range t {
+		io.WriteString(s, indent)
+		io.WriteString(s, strings.ReplaceAll(fmt.Sprintf("%+v", f), "\n", "\n"+indent))
+		io.WriteString(s, "\n")
+	}
+}
@@ -0,0 +1,80 @@
+package callers
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func testFrame() *Frame {
+	return &Frame{
+		File:     "import/path/callers.go",
+		Line:     42,
+		Function: "github.com/jgpruitt/callers.Callers",
+	}
+}
+
+func TestFrameFormatVerbs(t *testing.T) {
+	var f = testFrame()
+
+	var cases = []struct {
+		format string
+		want   string
+	}{
+		{"%s", "callers.go"},
+		{"%+s", "import/path/callers.go"},
+		{"%d", "42"},
+		{"%n", "Callers"},
+		{"%k", "callers"},
+		{"%v", f.String()},
+		{"%+v", "github.com/jgpruitt/callers.Callers\n\timport/path/callers.go:42"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.format, func(t *testing.T) {
+			if got := fmt.Sprintf(c.format, f); got != c.want {
+				t.Errorf("Sprintf(%q, f) = %q, want %q", c.format, got, c.want)
+			}
+		})
+	}
+}
+
+func TestTraceFormatV(t *testing.T) {
+	var trace = Trace{testFrame(), testFrame()}
+
+	if got := fmt.Sprintf("%v", trace); got != trace.String() {
+		t.Errorf("Sprintf(%%v, trace) = %q, want %q", got, trace.String())
+	}
+}
+
+func TestTraceFormatPlusV(t *testing.T) {
+	var trace = Trace{testFrame(), testFrame()}
+
+	var got = fmt.Sprintf("%+v", trace)
+	var lines = strings.Split(strings.TrimRight(got, "\n"), "\n")
+	// two frames, each rendered across two lines by Frame's %+v
+	if len(lines) != 4 {
+		t.Fatalf("got %d lines, want 4:\n%s", len(lines), got)
+	}
+}
+
+func TestTraceFormatPlusVWidthIndents(t *testing.T) {
+	var trace = Trace{testFrame()}
+
+	var got = fmt.Sprintf("%+2v", trace)
+	if !strings.HasPrefix(got, "  ") {
+		t.Errorf("Sprintf(%%+2v, trace) = %q, want it to start with a 2-space indent", got)
+	}
+}
+
+func TestFuncname(t *testing.T) {
+	if got := funcname("github.com/jgpruitt/callers.Callers"); got != "Callers" {
+		t.Errorf("funcname = %q, want %q", got, "Callers")
+	}
+}
+
+func TestPkgname(t *testing.T) {
+	if got := pkgname("github.com/jgpruitt/callers.Callers"); got != "callers" {
+		t.Errorf("pkgname = %q, want %q", got, "callers")
+	}
+}
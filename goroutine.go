@@ -0,0 +1,133 @@
+package callers
+
+import (
+	"bufio"
+	"bytes"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// goroutineHeaderPrefix is the prefix of the first line of a
+// runtime.Stack dump for a single goroutine, e.g. "goroutine 1 [running]:".
+const goroutineHeaderPrefix = "goroutine "
+
+// Goroutine captures the stack trace of the calling goroutine by parsing
+// the textual dump produced by runtime.Stack, the same way
+// runtime/debug.Stack does. It's useful for capturing frames when
+// Callers isn't an option, e.g. while handling a panic.
+func Goroutine() []*Frame {
+	var _, frames = parseGoroutine(stackDump(false))
+	return frames
+}
+
+// AllGoroutines captures the stack trace of every running goroutine,
+// keyed by goroutine ID, by parsing the textual dump produced by
+// runtime.Stack. It's useful for deadlock diagnostics and panic dumps.
+func AllGoroutines() map[int64][]*Frame {
+	var result = make(map[int64][]*Frame)
+	for _, chunk := range splitGoroutines(stackDump(true)) {
+		var id, frames = parseGoroutine(chunk)
+		result[id] = frames
+	}
+	return result
+}
+
+// stackDump grows buf until the dump produced by runtime.Stack fits
+// entirely within it, the same way runtime/debug.Stack does.
+func stackDump(all bool) []byte {
+	var buf = make([]byte, 1<<14)
+	for {
+		var n = runtime.Stack(buf, all)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// splitGoroutines splits the dump produced by runtime.Stack(buf, true)
+// into one chunk per goroutine; goroutine dumps are separated by a blank
+// line.
+func splitGoroutines(dump []byte) [][]byte {
+	var pieces = bytes.Split(bytes.TrimRight(dump, "\n"), []byte("\n\n"))
+	var chunks = make([][]byte, 0, len(pieces))
+	for _, p := range pieces {
+		if len(bytes.TrimSpace(p)) > 0 {
+			chunks = append(chunks, p)
+		}
+	}
+	return chunks
+}
+
+// parseGoroutine parses a single "goroutine N [state]:" dump into its ID
+// and frames.
+func parseGoroutine(chunk []byte) (id int64, frames []*Frame) {
+	var scanner = bufio.NewScanner(bytes.NewReader(chunk))
+	if !scanner.Scan() {
+		return 0, nil
+	}
+	id, _ = parseGoroutineHeader(scanner.Text())
+
+	frames = make([]*Frame, 0)
+	for scanner.Scan() {
+		var fnLine = scanner.Text()
+		if !scanner.Scan() {
+			break
+		}
+		frames = append(frames, parseFrame(fnLine, scanner.Text()))
+	}
+	return id, frames
+}
+
+// parseGoroutineHeader extracts the goroutine ID and state from a line
+// like "goroutine 1 [running]:".
+func parseGoroutineHeader(header string) (id int64, state string) {
+	header = strings.TrimPrefix(header, goroutineHeaderPrefix)
+	var idStr, rest, _ = strings.Cut(header, " ")
+	id, _ = strconv.ParseInt(idStr, 10, 64)
+	rest = strings.TrimPrefix(rest, "[")
+	state = strings.TrimSuffix(rest, "]:")
+	return id, state
+}
+
+// parseFrame turns a function-call line and its following file:line line
+// into a Frame, e.g.
+//
+//	main.main()
+//		/home/user/src/main.go:10 +0x1a
+//
+// or, for the goroutine's creator frame on Go 1.21+:
+//
+//	created by main.worker in goroutine 7
+//		/home/user/src/main.go:20 +0x1a
+func parseFrame(fnLine, locLine string) *Frame {
+	var function = fnLine
+	if strings.HasPrefix(fnLine, "created by ") {
+		function = strings.TrimPrefix(fnLine, "created by ")
+		if n := strings.LastIndex(function, " in goroutine "); n >= 0 {
+			function = function[:n]
+		}
+	}
+	if n := strings.LastIndex(function, "("); n >= 0 {
+		function = function[:n]
+	}
+
+	var loc = strings.TrimSpace(locLine)
+	if n := strings.LastIndex(loc, " "); n >= 0 {
+		loc = loc[:n] // drop the trailing " +0x..." pc offset
+	}
+	var file = loc
+	var line int
+	if n := strings.LastIndex(loc, ":"); n >= 0 {
+		file = loc[:n]
+		line, _ = strconv.Atoi(loc[n+1:])
+	}
+
+	return &Frame{
+		File:     trimFile(file),
+		Line:     line,
+		Function: function,
+		absFile:  file,
+	}
+}
@@ -0,0 +1,70 @@
+package callers
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseFrame(t *testing.T) {
+	var cases = []struct {
+		name     string
+		fnLine   string
+		locLine  string
+		wantFunc string
+		wantLine int
+	}{
+		{
+			name:     "plain call",
+			fnLine:   "main.main()",
+			locLine:  "\t/home/user/src/main.go:10 +0x1a",
+			wantFunc: "main.main",
+			wantLine: 10,
+		},
+		{
+			name:     "created by, pre-1.21",
+			fnLine:   "created by main.worker",
+			locLine:  "\t/home/user/src/main.go:20 +0x1a",
+			wantFunc: "main.worker",
+			wantLine: 20,
+		},
+		{
+			name:     "created by, Go 1.21+ goroutine suffix",
+			fnLine:   "created by main.worker in goroutine 7",
+			locLine:  "\t/home/user/src/main.go:20 +0x1a",
+			wantFunc: "main.worker",
+			wantLine: 20,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var frame = parseFrame(c.fnLine, c.locLine)
+			if frame.Function != c.wantFunc {
+				t.Errorf("Function = %q, want %q", frame.Function, c.wantFunc)
+			}
+			if frame.Line != c.wantLine {
+				t.Errorf("Line = %d, want %d", frame.Line, c.wantLine)
+			}
+		})
+	}
+}
+
+func TestAllGoroutinesTrimsCreatorSuffix(t *testing.T) {
+	var done = make(chan struct{})
+	go func() {
+		<-done
+	}()
+	defer close(done)
+
+	// give the runtime a moment to schedule the goroutine above
+	time.Sleep(10 * time.Millisecond)
+
+	for _, frames := range AllGoroutines() {
+		for _, f := range frames {
+			if strings.Contains(f.Function, "goroutine") {
+				t.Errorf("Function %q still contains the creator goroutine suffix", f.Function)
+			}
+		}
+	}
+}
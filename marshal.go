@@ -0,0 +1,25 @@
+package callers
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// frameJSON is the wire representation produced by Frame.MarshalJSON.
+type frameJSON struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+	Func string `json:"func"`
+}
+
+// MarshalJSON implements json.Marshaler, encoding f as
+// {"file":...,"line":...,"func":...}.
+func (f *Frame) MarshalJSON() ([]byte, error) {
+	return json.Marshal(frameJSON{File: f.File, Line: f.Line, Func: f.Function})
+}
+
+// Logfmt renders f as a single logfmt line, e.g.
+// "file=callers.go line=10 func=github.com/jgpruitt/callers.Callers".
+func (f *Frame) Logfmt() string {
+	return fmt.Sprintf("file=%s line=%d func=%s", f.File, f.Line, f.Function)
+}
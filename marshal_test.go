@@ -0,0 +1,54 @@
+package callers
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFrameMarshalJSON(t *testing.T) {
+	var f = &Frame{File: "callers.go", Line: 10, Function: "callers.Callers"}
+
+	var data, err = json.Marshal(f)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	if got["file"] != "callers.go" || got["func"] != "callers.Callers" {
+		t.Errorf("unmarshaled = %+v", got)
+	}
+	if got["line"].(float64) != 10 {
+		t.Errorf("line = %v, want 10", got["line"])
+	}
+}
+
+func TestTraceMarshalJSON(t *testing.T) {
+	var trace = Trace{
+		{File: "a.go", Line: 1, Function: "pkg.A"},
+		{File: "b.go", Line: 2, Function: "pkg.B"},
+	}
+
+	var data, err = json.Marshal(trace)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+
+	var got []map[string]interface{}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	if len(got) != 2 || got[0]["file"] != "a.go" || got[1]["file"] != "b.go" {
+		t.Errorf("unmarshaled = %+v", got)
+	}
+}
+
+func TestFrameLogfmt(t *testing.T) {
+	var f = &Frame{File: "callers.go", Line: 10, Function: "callers.Callers"}
+	var want = "file=callers.go line=10 func=callers.Callers"
+	if got := f.Logfmt(); got != want {
+		t.Errorf("Logfmt() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,129 @@
+package callers
+
+import (
+	"container/list"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// CallersWithSource is Callers plus best-effort source enrichment: for
+// every captured Frame it reads the frame's source file and attaches
+// contextLines lines of context around the call site via Frame.Source.
+// Frames whose source can't be read (stripped binaries, vendored stdlib
+// not present on disk, etc.) are left with no SourceLines; the error is
+// discarded since the trace itself is still useful without it.
+func CallersWithSource(skip, depth, contextLines int) (trace Trace) {
+	trace = Callers(skip+1, depth)
+	for _, f := range trace {
+		_, _ = f.Source(contextLines)
+	}
+	return trace
+}
+
+// Source reads the Frame's source file and returns contextLines lines of
+// context on either side of the call site, inclusive of the call site
+// itself. The result is also cached on the Frame as SourceLines. The
+// underlying file contents are cached in an LRU keyed by absolute path
+// so that capturing traces in a hot path doesn't repeatedly hit disk.
+func (f *Frame) Source(contextLines int) ([]string, error) {
+	var path = f.absFile
+	if path == "" {
+		path = f.File
+	}
+	var lines, err = sourceWindow(path, f.Line, contextLines)
+	if err != nil {
+		return nil, err
+	}
+	f.SourceLines = lines
+	return lines, nil
+}
+
+// sourceCache is the process-wide LRU of source file contents, shared by
+// every Frame.Source call.
+var sourceCache = newFileCache(64)
+
+// sourceWindow returns the lines of path from [line-1-contextLines,
+// line+contextLines), clamped to the file's bounds.
+func sourceWindow(path string, line, contextLines int) ([]string, error) {
+	var lines, err = sourceCache.lines(path)
+	if err != nil {
+		return nil, err
+	}
+	if line < 1 || line > len(lines) {
+		return nil, fmt.Errorf("callers: line %d out of range for %s", line, path)
+	}
+	if contextLines < 0 {
+		contextLines = 0
+	}
+	var start = line - 1 - contextLines
+	if start < 0 {
+		start = 0
+	}
+	var end = line + contextLines
+	if end > len(lines) {
+		end = len(lines)
+	}
+	return lines[start:end], nil
+}
+
+// fileCache is a simple LRU cache of source file contents, keyed by
+// absolute path.
+type fileCache struct {
+	mu       sync.Mutex
+	capacity int
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+// fileCacheEntry is the value stored in fileCache.ll; path is kept
+// alongside lines so a capacity eviction knows which map entry to drop.
+type fileCacheEntry struct {
+	path  string
+	lines []string
+}
+
+func newFileCache(capacity int) *fileCache {
+	return &fileCache{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// lines returns path's contents split on "\n", reading and caching the
+// file if it isn't already cached.
+func (c *fileCache) lines(path string) ([]string, error) {
+	c.mu.Lock()
+	if elem, ok := c.items[path]; ok {
+		c.ll.MoveToFront(elem)
+		var lines = elem.Value.(*fileCacheEntry).lines
+		c.mu.Unlock()
+		return lines, nil
+	}
+	c.mu.Unlock()
+
+	var data, err = os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var lines = strings.Split(string(data), "\n")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.items[path]; ok {
+		c.ll.MoveToFront(elem)
+		return elem.Value.(*fileCacheEntry).lines, nil
+	}
+	var elem = c.ll.PushFront(&fileCacheEntry{path: path, lines: lines})
+	c.items[path] = elem
+	if c.ll.Len() > c.capacity {
+		var oldest = c.ll.Back()
+		if oldest != nil {
+			c.ll.Remove(oldest)
+			delete(c.items, oldest.Value.(*fileCacheEntry).path)
+		}
+	}
+	return lines, nil
+}
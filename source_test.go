@@ -0,0 +1,142 @@
+package callers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTempFile(t *testing.T, contents string) string {
+	t.Helper()
+	var dir = t.TempDir()
+	var path = filepath.Join(dir, "source.txt")
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return path
+}
+
+func TestSourceWindow(t *testing.T) {
+	var path = writeTempFile(t, "one\ntwo\nthree\nfour\nfive")
+
+	var lines, err = sourceWindow(path, 3, 1)
+	if err != nil {
+		t.Fatalf("sourceWindow: %v", err)
+	}
+	var want = []string{"two", "three", "four"}
+	if len(lines) != len(want) {
+		t.Fatalf("lines = %v, want %v", lines, want)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("lines[%d] = %q, want %q", i, lines[i], want[i])
+		}
+	}
+}
+
+func TestSourceWindowClampsToFileBounds(t *testing.T) {
+	var path = writeTempFile(t, "one\ntwo\nthree")
+
+	var lines, err = sourceWindow(path, 1, 5)
+	if err != nil {
+		t.Fatalf("sourceWindow: %v", err)
+	}
+	if len(lines) != 3 {
+		t.Fatalf("lines = %v, want all 3 lines", lines)
+	}
+}
+
+func TestSourceWindowLineOutOfRange(t *testing.T) {
+	var path = writeTempFile(t, "one\ntwo")
+
+	if _, err := sourceWindow(path, 99, 0); err == nil {
+		t.Error("sourceWindow with an out-of-range line should return an error")
+	}
+}
+
+func TestFrameSourceMissingFile(t *testing.T) {
+	var f = &Frame{File: "does-not-exist.go", Line: 1, absFile: "/no/such/path/does-not-exist.go"}
+	var lines, err = f.Source(0)
+	if err == nil {
+		t.Fatal("Source() on a missing file should return an error")
+	}
+	if lines != nil {
+		t.Errorf("lines = %v, want nil", lines)
+	}
+	if f.SourceLines != nil {
+		t.Error("SourceLines should stay nil when Source fails")
+	}
+}
+
+func TestFrameSource(t *testing.T) {
+	var path = writeTempFile(t, "package x\n\nfunc f() {\n\tpanic(\"x\")\n}\n")
+	var f = &Frame{File: "source.txt", Line: 4, absFile: path}
+
+	var lines, err = f.Source(1)
+	if err != nil {
+		t.Fatalf("Source: %v", err)
+	}
+	if len(lines) != 3 || lines[1] != "\tpanic(\"x\")" {
+		t.Errorf("lines = %v", lines)
+	}
+	if len(f.SourceLines) != len(lines) {
+		t.Error("Source did not cache its result on SourceLines")
+	}
+}
+
+func TestCallersWithSource(t *testing.T) {
+	var trace = CallersWithSource(2, 1, 0)
+	if len(trace) == 0 {
+		t.Fatal("CallersWithSource returned no frames")
+	}
+	if len(trace[0].SourceLines) == 0 {
+		t.Error("CallersWithSource did not populate SourceLines for this test's own frame")
+	}
+}
+
+func TestFileCacheEviction(t *testing.T) {
+	var c = newFileCache(2)
+	var a = writeTempFile(t, "a")
+	var b = writeTempFile(t, "b")
+	var d = writeTempFile(t, "d")
+
+	if _, err := c.lines(a); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.lines(b); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.lines(d); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := c.items[a]; ok {
+		t.Error("least-recently-used entry a should have been evicted")
+	}
+	if _, ok := c.items[b]; !ok {
+		t.Error("b should still be cached")
+	}
+	if _, ok := c.items[d]; !ok {
+		t.Error("d should still be cached")
+	}
+}
+
+func TestFileCacheHit(t *testing.T) {
+	var c = newFileCache(2)
+	var path = writeTempFile(t, "one\ntwo")
+
+	var first, err = c.lines(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("changed"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var second, err2 = c.lines(path)
+	if err2 != nil {
+		t.Fatal(err2)
+	}
+	if len(second) != len(first) {
+		t.Error("second read should have come from the cache, not the rewritten file")
+	}
+}
@@ -0,0 +1,38 @@
+// Package zapcallers adapts callers.Frame and callers.Trace to zap's
+// structured logging interfaces. It's kept as a separate module so that
+// consumers of the dependency-free callers package don't transitively
+// pull in zap unless they ask for it.
+package zapcallers
+
+import (
+	"github.com/jgpruitt/callers"
+	"go.uber.org/zap/zapcore"
+)
+
+// Frame wraps a *callers.Frame so it implements zapcore.ObjectMarshaler.
+type Frame struct {
+	*callers.Frame
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler so a Frame can be
+// passed directly to zap.Object/zap.Inline.
+func (f Frame) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("file", f.File)
+	enc.AddInt("line", f.Line)
+	enc.AddString("func", f.Function)
+	return nil
+}
+
+// Trace wraps a callers.Trace so it implements zapcore.ArrayMarshaler.
+type Trace callers.Trace
+
+// MarshalLogArray implements zapcore.ArrayMarshaler so a Trace can be
+// passed directly to zap.Array.
+func (t Trace) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, f := range t {
+		if err := enc.AppendObject(Frame{f}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package zapcallers
+
+import (
+	"testing"
+
+	"github.com/jgpruitt/callers"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestFrameMarshalLogObject(t *testing.T) {
+	var core, logs = observer.New(zapcore.DebugLevel)
+	var logger = zap.New(core)
+
+	var frame = &callers.Frame{File: "callers.go", Line: 42, Function: "callers.Callers"}
+	logger.Debug("trace", zap.Object("frame", Frame{frame}))
+
+	var entries = logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("got %d log entries, want 1", len(entries))
+	}
+	var got, ok = entries[0].ContextMap()["frame"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("frame field is %T, want map[string]interface{}", entries[0].ContextMap()["frame"])
+	}
+	if got["file"] != "callers.go" {
+		t.Errorf("file = %v, want callers.go", got["file"])
+	}
+	if got["func"] != "callers.Callers" {
+		t.Errorf("func = %v, want callers.Callers", got["func"])
+	}
+	if line, _ := got["line"].(int); line != 42 {
+		t.Errorf("line = %v, want 42", got["line"])
+	}
+}
+
+func TestTraceMarshalLogArray(t *testing.T) {
+	var core, logs = observer.New(zapcore.DebugLevel)
+	var logger = zap.New(core)
+
+	var trace = Trace{
+		&callers.Frame{File: "a.go", Line: 1, Function: "pkg.A"},
+		&callers.Frame{File: "b.go", Line: 2, Function: "pkg.B"},
+	}
+	logger.Debug("trace", zap.Array("trace", trace))
+
+	var entries = logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("got %d log entries, want 1", len(entries))
+	}
+}